@@ -20,6 +20,7 @@ import (
 	"os"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -42,6 +43,7 @@ import (
 
 	"open-cluster-management.io/multicloud-operators-channel/pkg/apis"
 	"open-cluster-management.io/multicloud-operators-channel/pkg/controller"
+	chController "open-cluster-management.io/multicloud-operators-channel/pkg/controller/channel"
 	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
 	chWebhook "open-cluster-management.io/multicloud-operators-channel/pkg/webhook"
 )
@@ -149,6 +151,13 @@ func RunManager() {
 	// Setup all Controllers
 	logger.Info("Setting up controller")
 
+	chController.ConflictBackoff = wait.Backoff{
+		Duration: options.ConflictBackoffDuration,
+		Factor:   options.ConflictBackoffFactor,
+		Steps:    options.ConflictBackoffSteps,
+		Cap:      options.ConflictBackoffCap,
+	}
+
 	if err := controller.AddToManager(mgr, dynamicClient, recorder, logf.Log.WithName("controllers"), chdesc); err != nil {
 		logger.Error(err, "unable to register controllers to the manager")
 		os.Exit(exitCode)
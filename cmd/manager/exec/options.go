@@ -14,26 +14,39 @@
 package exec
 
 import (
+	"time"
+
 	pflag "github.com/spf13/pflag"
+
+	chController "open-cluster-management.io/multicloud-operators-channel/pkg/controller/channel"
 )
 
 const defaultSyncInterval = 60 //seconds
 
 // ChannelCMDOptions for command line flag parsing
 type ChannelCMDOptions struct {
-	MetricsAddr  string
-	SyncInterval int
-	LeaderElect  bool
-	Debug        bool
-	LogLevel     bool
+	MetricsAddr             string
+	SyncInterval            int
+	LeaderElect             bool
+	Debug                   bool
+	LogLevel                bool
+	ConflictBackoffDuration time.Duration
+	ConflictBackoffFactor   float64
+	ConflictBackoffSteps    int
+	ConflictBackoffCap      time.Duration
 }
 
+// the conflict backoff defaults are owned by the channel controller
 var (
 	options = ChannelCMDOptions{
-		MetricsAddr:  "",
-		SyncInterval: defaultSyncInterval,
-		Debug:        false,
-		LogLevel:     false,
+		MetricsAddr:             "",
+		SyncInterval:            defaultSyncInterval,
+		Debug:                   false,
+		LogLevel:                false,
+		ConflictBackoffDuration: chController.ConflictBackoff.Duration,
+		ConflictBackoffFactor:   chController.ConflictBackoff.Factor,
+		ConflictBackoffSteps:    chController.ConflictBackoff.Steps,
+		ConflictBackoffCap:      chController.ConflictBackoff.Cap,
 	}
 )
 
@@ -91,4 +104,32 @@ func ProcessFlags() {
 		false,
 		"zap-devel, default only log INFO(fasle), set to true for debugging",
 	)
+
+	flag.DurationVar(
+		&options.ConflictBackoffDuration,
+		"conflict-backoff-duration",
+		options.ConflictBackoffDuration,
+		"The initial requeue delay of a channel reconcile hitting an update conflict.",
+	)
+
+	flag.Float64Var(
+		&options.ConflictBackoffFactor,
+		"conflict-backoff-factor",
+		options.ConflictBackoffFactor,
+		"The factor the requeue delay is multiplied by on each consecutive conflict.",
+	)
+
+	flag.IntVar(
+		&options.ConflictBackoffSteps,
+		"conflict-backoff-steps",
+		options.ConflictBackoffSteps,
+		"The number of consecutive conflicts the requeue delay keeps increasing for.",
+	)
+
+	flag.DurationVar(
+		&options.ConflictBackoffCap,
+		"conflict-backoff-cap",
+		options.ConflictBackoffCap,
+		"The maximum requeue delay of a channel reconcile hitting update conflicts.",
+	)
 }
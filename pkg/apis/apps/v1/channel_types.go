@@ -37,6 +37,16 @@ var (
 	// ServingChannel indicates the channel that the secrect or configMap
 	// reference.
 	ServingChannel = SchemeGroupVersion.Group + "/serving-channel"
+
+	// KeyChannelVersionConstraint is the semver range a chart version of
+	// HelmRepo type channel has to satisfy, i.e. ">=2.0.0 <3.0.0". It is
+	// read by the subscription operator through utils.GetChannelHelmRepoIndex
+	// when syncing the channel charts.
+	KeyChannelVersionConstraint = SchemeGroupVersion.Group + "/version-constraint"
+
+	// ChannelCleanupFinalizer holds the channel deletion until the resources
+	// generated for the channel are cleaned up
+	ChannelCleanupFinalizer = SchemeGroupVersion.Group + "/channel-cleanup"
 )
 
 // ChannelType defines types of channel
@@ -113,8 +123,16 @@ type ChannelSpec struct {
 	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
 }
 
+const (
+	// ChannelConditionCredentialsMissing indicates the secret referenced by the channel is not found
+	ChannelConditionCredentialsMissing = "CredentialsMissing"
+)
+
 // ChannelStatus defines the observed state of Channel
 type ChannelStatus struct {
+	// Conditions of the channel, i.e. `CredentialsMissing` when the referenced secret is not found.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +genclient
@@ -243,7 +243,24 @@ func schema_pkg_apis_apps_v1_ChannelStatus(ref common.ReferenceCallback) common.
 			SchemaProps: spec.SchemaProps{
 				Description: "ChannelStatus defines the observed state of Channel",
 				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions of the channel, i.e. `CredentialsMissing` when the referenced secret is not found.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
 }
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
 	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
@@ -33,12 +35,12 @@ import (
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metaerr "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,12 +71,27 @@ var (
 	// DeployableAnnotation = dplv1.SchemeGroupVersion.Group + "/deployables" TODO ocm
 	srtGvk = schema.GroupVersionKind{Group: "", Kind: "Secret", Version: "v1"}
 	cmGvk  = schema.GroupVersionKind{Group: "", Kind: "ConfigMap", Version: "v1"}
+
+	// ConflictBackoff is the requeue backoff of a channel whose reconcile keeps hitting update conflicts,
+	// it is reset once the channel reconciles without a conflict
+	ConflictBackoff = wait.Backoff{
+		Duration: time.Second,
+		Factor:   2,
+		Steps:    10,
+		Cap:      time.Minute,
+	}
 )
 
 const (
 	clusterCRDName  = "clusters.clusterregistry.k8s.io"
 	controllerName  = "channel"
 	controllerSetup = "channel-setup"
+
+	reasonSecretNotFound         = "SecretNotFound"
+	reasonSecretFound            = "SecretFound"
+	reasonSecretInvalidReference = "SecretInvalidReference"
+
+	secretRefIndex = "spec.secretRef"
 )
 
 /**
@@ -98,6 +115,10 @@ func newReconciler(mgr manager.Manager, dynamicClient dynamic.Interface, recorde
 		scheme:        mgr.GetScheme(),
 		Recorder:      recorder,
 		Log:           logger,
+		conflictBackoff: &conflictBackoff{
+			backoff:  ConflictBackoff,
+			channels: make(map[types.NamespacedName]*wait.Backoff),
+		},
 	}
 }
 
@@ -120,6 +141,22 @@ func add(mgr manager.Manager, r reconcile.Reconciler, logger logr.Logger) error
 		return err
 	}
 
+	// index the channels by the referred secret, so a secret event only looks up the channels referring to it
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &chv1.Channel{}, secretRefIndex, indexChannelSecretRef); err != nil {
+		return err
+	}
+
+	// Watch for changes to the referenced Secret, so the channel credentials condition follows its deletion and recreation.
+	// The manager caches all the secrets for this watch, the referred secrets and configmaps are read through the same
+	// typed cache
+	err = c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: &secretMapper{Client: mgr.GetClient(), logger: logger}},
+	)
+	if err != nil {
+		return err
+	}
+
 	// TODO ocm
 	// if placementutils.IsReadyACMClusterRegistry(mgr.GetAPIReader()) {
 	// 	err = c.Watch(
@@ -166,15 +203,90 @@ func (mapper *clusterMapper) Map(obj handler.MapObject) []reconcile.Request {
 	return requests
 }
 
+type secretMapper struct {
+	client.Client
+	logger logr.Logger
+}
+
+// Map triggers the channels referring to the secret
+func (mapper *secretMapper) Map(obj handler.MapObject) []reconcile.Request {
+	srtKey := types.NamespacedName{Name: obj.Meta.GetName(), Namespace: obj.Meta.GetNamespace()}
+
+	chList := &chv1.ChannelList{}
+	if err := mapper.List(context.TODO(), chList, client.MatchingFields{secretRefIndex: srtKey.String()}); err != nil {
+		mapper.logger.Error(err, "failed to list channels")
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for _, ch := range chList.Items {
+		objkey := types.NamespacedName{
+			Name:      ch.GetName(),
+			Namespace: ch.GetNamespace(),
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: objkey})
+	}
+
+	return requests
+}
+
+// indexChannelSecretRef returns the namespaced name of the secret referred by the channel, a secret outside
+// of the channel namespace is not referred to, same as utils.ResolveChannelSecret
+func indexChannelSecretRef(obj runtime.Object) []string {
+	ch, ok := obj.(*chv1.Channel)
+	if !ok || ch.Spec.SecretRef == nil {
+		return nil
+	}
+
+	srtRef := ch.Spec.SecretRef
+	if srtRef.Name == "" || (srtRef.Namespace != "" && srtRef.Namespace != ch.GetNamespace()) {
+		return nil
+	}
+
+	return []string{types.NamespacedName{Name: srtRef.Name, Namespace: ch.GetNamespace()}.String()}
+}
+
 var _ reconcile.Reconciler = &ReconcileChannel{}
 
 // ReconcileChannel reconciles a Channel object
 type ReconcileChannel struct {
 	client.Client
-	DynamicClient dynamic.Interface
-	scheme        *runtime.Scheme
-	Recorder      record.EventRecorder
-	Log           logr.Logger
+	DynamicClient   dynamic.Interface
+	scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	Log             logr.Logger
+	conflictBackoff *conflictBackoff
+}
+
+// conflictBackoff tracks the requeue backoff of the channels hitting update conflicts
+type conflictBackoff struct {
+	sync.Mutex
+	backoff  wait.Backoff
+	channels map[types.NamespacedName]*wait.Backoff
+}
+
+// next returns the next requeue duration of the channel
+func (cb *conflictBackoff) next(key types.NamespacedName) time.Duration {
+	cb.Lock()
+	defer cb.Unlock()
+
+	b, ok := cb.channels[key]
+	if !ok {
+		b = &wait.Backoff{}
+		*b = cb.backoff
+		cb.channels[key] = b
+	}
+
+	return b.Step()
+}
+
+// reset starts the backoff of the channel over
+func (cb *conflictBackoff) reset(key types.NamespacedName) {
+	cb.Lock()
+	delete(cb.channels, key)
+	cb.Unlock()
 }
 
 // Reconcile reads that state of the cluster for a Channel object and makes changes based on the state read
@@ -186,6 +298,31 @@ type ReconcileChannel struct {
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=channels,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=channels/status,verbs=get;update;patch
 func (r *ReconcileChannel) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconcile(request)
+
+	return r.requeueOnConflict(request.NamespacedName, result, err)
+}
+
+// requeueOnConflict requeues the channel with an increasing delay while its reconcile keeps hitting conflicts,
+// instead of retrying right away and adding to the contention
+func (r *ReconcileChannel) requeueOnConflict(key types.NamespacedName, result reconcile.Result, err error) (reconcile.Result, error) {
+	if r.conflictBackoff == nil {
+		return result, err
+	}
+
+	if !kerr.IsConflict(err) {
+		r.conflictBackoff.reset(key)
+		return result, err
+	}
+
+	requeueAfter := r.conflictBackoff.next(key)
+
+	r.Log.Info(fmt.Sprintf("conflict while reconciling channel %v, requeue after %v. error: %v", key.String(), requeueAfter, err))
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *ReconcileChannel) reconcile(request reconcile.Request) (reconcile.Result, error) {
 	log := r.Log.WithValues("channel-reconcile", request.NamespacedName)
 
 	log.Info(fmt.Sprintf("Starting %v reconcile loop for %v", controllerName, request.NamespacedName))
@@ -220,6 +357,16 @@ func (r *ReconcileChannel) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{}, nil
 	}
 
+	// the role and rolebinding are generated into the channel namespace, skip if it is gone or going away
+	if err := utils.ValidateChannelNamespaceExists(r.Client, instance); err != nil {
+		if gerr.Is(err, utils.ErrChannelNamespaceUnavailable) {
+			log.Info(fmt.Sprintf("skipping the reconcile of channel %v, %v", request.NamespacedName, err))
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
 	// find the channel controller pod namespace, it is running in the ACM namespece
 	mchNamespace := r.FindMultiClusterHubNS(log)
 
@@ -229,7 +376,13 @@ func (r *ReconcileChannel) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{}, err
 	}
 
-	r.handleReferencedObjects(instance, request, log)
+	srtMissing, err := r.syncCredentialsCondition(instance, log)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("failed to update the credentials condition of channel %v", instance.Name))
+		return reconcile.Result{}, err
+	}
+
+	r.handleReferencedObjects(instance, request, srtMissing, log)
 
 	err = r.cleanRoleFromAcmNS(instance, log, mchNamespace)
 	if err != nil {
@@ -240,12 +393,72 @@ func (r *ReconcileChannel) Reconcile(request reconcile.Request) (reconcile.Resul
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileChannel) handleReferencedObjects(instance *chv1.Channel, req reconcile.Request, log logr.Logger) {
+// syncCredentialsCondition sets the CredentialsMissing condition of the channel by the existence of its
+// referenced secret, and returns true if the referenced secret is missing or can't be referred to
+func (r *ReconcileChannel) syncCredentialsCondition(instance *chv1.Channel, log logr.Logger) (bool, error) {
+	conditions := append([]metav1.Condition(nil), instance.Status.Conditions...)
+
+	srtMissing := false
+
+	if instance.Spec.SecretRef == nil {
+		metaerr.RemoveStatusCondition(&conditions, chv1.ChannelConditionCredentialsMissing)
+	} else {
+		srt, err := utils.ResolveChannelSecret(r.Client, instance)
+
+		switch {
+		case kerr.IsNotFound(gerr.Cause(err)):
+			srtMissing = true
+
+			metaerr.SetStatusCondition(&conditions, metav1.Condition{
+				Type:    chv1.ChannelConditionCredentialsMissing,
+				Status:  metav1.ConditionTrue,
+				Reason:  reasonSecretNotFound,
+				Message: err.Error(),
+			})
+		case gerr.Is(err, utils.ErrInvalidChannelReference):
+			srtMissing = true
+
+			metaerr.SetStatusCondition(&conditions, metav1.Condition{
+				Type:    chv1.ChannelConditionCredentialsMissing,
+				Status:  metav1.ConditionTrue,
+				Reason:  reasonSecretInvalidReference,
+				Message: err.Error(),
+			})
+		case err != nil:
+			return false, err
+		default:
+			metaerr.SetStatusCondition(&conditions, metav1.Condition{
+				Type:    chv1.ChannelConditionCredentialsMissing,
+				Status:  metav1.ConditionFalse,
+				Reason:  reasonSecretFound,
+				Message: fmt.Sprintf("the referred secret %v/%v is found", srt.GetNamespace(), srt.GetName()),
+			})
+		}
+	}
+
+	if reflect.DeepEqual(conditions, instance.Status.Conditions) {
+		return srtMissing, nil
+	}
+
+	instance.Status.Conditions = conditions
+
+	if err := r.Update(context.TODO(), instance); err != nil {
+		return srtMissing, gerr.Wrap(err, "failed to update channel conditions")
+	}
+
+	log.Info(fmt.Sprintf("updated channel %v/%v condition %v, secret missing: %v", instance.GetNamespace(), instance.GetName(),
+		chv1.ChannelConditionCredentialsMissing, srtMissing))
+
+	return srtMissing, nil
+}
+
+func (r *ReconcileChannel) handleReferencedObjects(instance *chv1.Channel, req reconcile.Request, srtMissing bool, log logr.Logger) {
 	// If the channel has relative secret and configMap, annotate the channel info in the secret and configMap
 	//sync the channel to the serving-channel annotation in all involved secrets.
 	srtRef := instance.Spec.SecretRef
 
-	if srtRef != nil {
+	// a missing or invalid secret has nothing to label, wait for the secret watch or a spec change to bring it back
+	if srtRef != nil && !srtMissing {
 		if srtRef.Namespace == "" {
 			srtRef.Namespace = instance.GetNamespace()
 		}
@@ -261,7 +474,21 @@ func (r *ReconcileChannel) handleReferencedObjects(instance *chv1.Channel, req r
 
 	//	//sync the channel to the serving-channel annotation in all involved ConfigMaps.
 	cmRef := instance.Spec.ConfigMapRef
-	if cmRef != nil {
+
+	// the configmap follows the same reference rule as the secret, a missing or invalid one is skipped
+	cmMissing := false
+
+	if _, err := utils.ResolveChannelConfigMap(r.Client, instance); err != nil {
+		cmMissing = true
+
+		if kerr.IsNotFound(gerr.Cause(err)) || gerr.Is(err, utils.ErrInvalidChannelReference) {
+			log.Info(fmt.Sprintf("skip the referred configmap of channel %v/%v: %v", instance.GetNamespace(), instance.GetName(), err))
+		} else {
+			r.Log.Error(err, "failed to get referred configMap")
+		}
+	}
+
+	if cmRef != nil && !cmMissing {
 		if cmRef.Namespace == "" {
 			cmRef.Namespace = instance.GetNamespace()
 		}
@@ -284,22 +511,29 @@ func (r *ReconcileChannel) updatedReferencedObjectLabels(ref *corev1.ObjectRefer
 	objName := ref.Name
 	objNs := ref.Namespace
 
-	obj := &unstructured.Unstructured{}
-	objKey := types.NamespacedName{Name: objName, Namespace: objNs}
+	obj, _, err := newReferredObject(objGvk)
+	if err != nil {
+		return err
+	}
 
-	obj.SetGroupVersionKind(objGvk)
+	objKey := types.NamespacedName{Name: objName, Namespace: objNs}
 
 	if err := r.Get(context.TODO(), objKey, obj); err != nil {
 		return gerr.Wrapf(err, "failed to get the reference object %v", objGvk.Kind)
 	}
 
-	localLabels := obj.GetLabels()
+	objMeta, err := metaerr.Accessor(obj)
+	if err != nil {
+		return gerr.Wrapf(err, "failed to access the reference object %v", objGvk.Kind)
+	}
+
+	localLabels := objMeta.GetLabels()
 	if localLabels == nil {
 		localLabels = make(map[string]string)
 	}
 
 	localLabels[chv1.ServingChannel] = "true"
-	obj.SetLabels(localLabels)
+	objMeta.SetLabels(localLabels)
 
 	if err := r.Update(context.TODO(), obj); err != nil {
 		return gerr.Wrapf(err, "failed to update the referred object %v", objGvk.Kind)
@@ -310,14 +544,28 @@ func (r *ReconcileChannel) updatedReferencedObjectLabels(ref *corev1.ObjectRefer
 	return nil
 }
 
+// newReferredObject returns the typed object and list of the referred kind, so the referred objects are read from
+// the same cache as the secret watch instead of a second, unstructured copy
+func newReferredObject(objGvk schema.GroupVersionKind) (runtime.Object, runtime.Object, error) {
+	switch objGvk {
+	case srtGvk:
+		return &corev1.Secret{}, &corev1.SecretList{}, nil
+	case cmGvk:
+		return &corev1.ConfigMap{}, &corev1.ConfigMapList{}, nil
+	}
+
+	return nil, nil, gerr.Errorf("unsupported referred object %v", objGvk.String())
+}
+
 func (r *ReconcileChannel) syncReferredObjAnnotation(
 	rq reconcile.Request,
 	ref *corev1.ObjectReference, objGvk schema.GroupVersionKind, logger logr.Logger) error {
 	chnKey := types.NamespacedName{Name: rq.Name, Namespace: rq.Namespace}
 
-	uObjList := &unstructured.UnstructuredList{}
-
-	uObjList.SetGroupVersionKind(objGvk)
+	_, objList, err := newReferredObject(objGvk)
+	if err != nil {
+		return err
+	}
 
 	opts := &client.ListOptions{}
 
@@ -334,12 +582,21 @@ func (r *ReconcileChannel) syncReferredObjAnnotation(
 
 	opts.LabelSelector = clSelector
 
-	if err := r.Client.List(context.TODO(), uObjList, opts); err != nil {
+	if err := r.Client.List(context.TODO(), objList, opts); err != nil {
 		return gerr.Wrapf(err, "failed to list objects %v. error: ", objGvk.String())
 	}
 
-	for _, obj := range uObjList.Items {
-		obj := obj
+	items, err := metaerr.ExtractList(objList)
+	if err != nil {
+		return gerr.Wrapf(err, "failed to extract objects %v", objGvk.String())
+	}
+
+	for _, item := range items {
+		obj, err := metaerr.Accessor(item)
+		if err != nil {
+			return gerr.Wrapf(err, "failed to access object %v", objGvk.String())
+		}
+
 		annotations := obj.GetAnnotations()
 
 		if annotations == nil {
@@ -364,7 +621,7 @@ func (r *ReconcileChannel) syncReferredObjAnnotation(
 
 		obj.SetAnnotations(annotations)
 
-		if err := r.Update(context.TODO(), &obj); err != nil {
+		if err := r.Update(context.TODO(), item); err != nil {
 			logger.Error(err, fmt.Sprintf("failed to annotate object: %v/%v", obj.GetNamespace(), obj.GetName()))
 		}
 	}
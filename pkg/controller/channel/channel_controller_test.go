@@ -15,24 +15,32 @@
 package channel
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	tlog "github.com/go-logr/logr/testing"
 	"github.com/onsi/gomega"
+	gerr "github.com/pkg/errors"
 	"golang.org/x/net/context"
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metaerr "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -330,3 +338,275 @@ func TestChannelReconcileWithoutClusterCRD(t *testing.T) {
 		types.NamespacedName{Name: chn.Name, Namespace: chn.Namespace},
 		expectedRoleBinding)).NotTo(gomega.HaveOccurred())
 }
+
+// test the CredentialsMissing condition follows the deletion and recreation of the referred secret
+func TestChannelReconcileWithDeletedSecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	refSrtName := "ch-missing-srt"
+	refSrt := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      refSrtName,
+			Namespace: targetNamespace,
+		},
+	}
+
+	chKey := types.NamespacedName{Name: tragetChannelName, Namespace: targetNamespace}
+	chn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{Name: tragetChannelName, Namespace: targetNamespace},
+		Spec: chv1.ChannelSpec{
+			Type:      targetChannelType,
+			Pathname:  targetNamespace,
+			SecretRef: &corev1.ObjectReference{Name: refSrtName, Kind: "secret"},
+		},
+	}
+
+	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: "0"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	c = mgr.GetClient()
+
+	tRecorder := record.NewBroadcaster().NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "channel"})
+
+	stopMgr, mgrStopped := StartTestManager(mgr, g)
+
+	defer func() {
+		close(stopMgr)
+		mgrStopped.Wait()
+	}()
+
+	// Create dynamic client
+	dynamicClient := dynamic.NewForConfigOrDie(cfg)
+
+	rec := newReconciler(mgr, dynamicClient, tRecorder, tlog.NullLogger{})
+
+	g.Expect(c.Create(context.TODO(), refSrt)).NotTo(gomega.HaveOccurred())
+
+	defer c.Delete(context.TODO(), chn)
+	g.Expect(c.Create(context.TODO(), chn)).NotTo(gomega.HaveOccurred())
+
+	rq := reconcile.Request{NamespacedName: chKey}
+
+	credentialsMissing := func() metav1.ConditionStatus {
+		// the cached channel can be stale right after an update, let the retry pick it up
+		if _, err := rec.Reconcile(rq); err != nil {
+			return metav1.ConditionUnknown
+		}
+
+		updatedChn := &chv1.Channel{}
+		if err := c.Get(context.TODO(), chKey, updatedChn); err != nil {
+			return metav1.ConditionUnknown
+		}
+
+		cond := metaerr.FindStatusCondition(updatedChn.Status.Conditions, chv1.ChannelConditionCredentialsMissing)
+		if cond == nil {
+			return metav1.ConditionUnknown
+		}
+
+		return cond.Status
+	}
+
+	g.Eventually(credentialsMissing, timeout).Should(gomega.Equal(metav1.ConditionFalse))
+
+	g.Expect(c.Delete(context.TODO(), refSrt)).NotTo(gomega.HaveOccurred())
+	g.Eventually(credentialsMissing, timeout).Should(gomega.Equal(metav1.ConditionTrue))
+
+	refSrt.ResourceVersion = ""
+	defer c.Delete(context.TODO(), refSrt)
+	g.Expect(c.Create(context.TODO(), refSrt)).NotTo(gomega.HaveOccurred())
+	g.Eventually(credentialsMissing, timeout).Should(gomega.Equal(metav1.ConditionFalse))
+}
+
+func TestSecretMapper(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: "0"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	c = mgr.GetClient()
+
+	g.Expect(mgr.GetFieldIndexer().IndexField(context.TODO(), &chv1.Channel{}, secretRefIndex,
+		indexChannelSecretRef)).NotTo(gomega.HaveOccurred())
+
+	stopMgr, mgrStopped := StartTestManager(mgr, g)
+
+	defer func() {
+		close(stopMgr)
+		mgrStopped.Wait()
+	}()
+
+	refSrt := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ch-mapped-srt",
+			Namespace: targetNamespace,
+		},
+	}
+
+	chn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{Name: tragetChannelName, Namespace: targetNamespace},
+		Spec: chv1.ChannelSpec{
+			Type:      targetChannelType,
+			Pathname:  targetNamespace,
+			SecretRef: &corev1.ObjectReference{Name: refSrt.Name, Kind: "secret"},
+		},
+	}
+
+	defer c.Delete(context.TODO(), chn)
+	g.Expect(c.Create(context.TODO(), chn)).NotTo(gomega.HaveOccurred())
+
+	mapper := &secretMapper{Client: c, logger: tlog.NullLogger{}}
+
+	g.Eventually(func() []reconcile.Request {
+		return mapper.Map(handler.MapObject{Meta: refSrt, Object: refSrt})
+	}, timeout).Should(gomega.ConsistOf(expectedRequest))
+
+	otherSrt := refSrt.DeepCopy()
+	otherSrt.SetNamespace("ch-other")
+	g.Expect(mapper.Map(handler.MapObject{Meta: otherSrt, Object: otherSrt})).To(gomega.BeEmpty())
+}
+
+func TestIndexChannelSecretRef(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		srtRef *corev1.ObjectReference
+		want   []string
+	}{
+		{
+			desc:   "without secret",
+			srtRef: nil,
+			want:   nil,
+		},
+		{
+			desc:   "secret in the channel namespace",
+			srtRef: &corev1.ObjectReference{Name: "srt"},
+			want:   []string{targetNamespace + "/srt"},
+		},
+		{
+			desc:   "secret with explicit namespace",
+			srtRef: &corev1.ObjectReference{Name: "srt", Namespace: targetNamespace},
+			want:   []string{targetNamespace + "/srt"},
+		},
+		{
+			desc:   "secret in another namespace",
+			srtRef: &corev1.ObjectReference{Name: "srt", Namespace: "kube-system"},
+			want:   nil,
+		},
+		{
+			desc:   "secret without name",
+			srtRef: &corev1.ObjectReference{Namespace: targetNamespace},
+			want:   nil,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			chn := &chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{Name: tragetChannelName, Namespace: targetNamespace},
+				Spec: chv1.ChannelSpec{
+					Type:      targetChannelType,
+					SecretRef: tC.srtRef,
+				},
+			}
+
+			if got := indexChannelSecretRef(chn); !reflect.DeepEqual(got, tC.want) {
+				t.Errorf("indexChannelSecretRef() wanted %v, got %v", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestHandleReferencedConfigMap(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cmRef       *corev1.ObjectReference
+		wantLabeled types.NamespacedName
+	}{
+		{
+			desc:        "configmap in the channel namespace",
+			cmRef:       &corev1.ObjectReference{Name: "cm"},
+			wantLabeled: types.NamespacedName{Name: "cm", Namespace: targetNamespace},
+		},
+		{
+			desc:  "configmap in another namespace",
+			cmRef: &corev1.ObjectReference{Name: "cm", Namespace: "kube-system"},
+		},
+		{
+			desc:  "missing configmap",
+			cmRef: &corev1.ObjectReference{Name: "cm-missing"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cms := []types.NamespacedName{{Name: "cm", Namespace: targetNamespace}, {Name: "cm", Namespace: "kube-system"}}
+
+			objs := []runtime.Object{}
+			for _, key := range cms {
+				objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+			}
+
+			r := &ReconcileChannel{Client: fake.NewFakeClientWithScheme(scheme.Scheme, objs...), Log: tlog.NullLogger{}}
+
+			chn := &chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{Name: tragetChannelName, Namespace: targetNamespace},
+				Spec: chv1.ChannelSpec{
+					Type:         targetChannelType,
+					ConfigMapRef: tC.cmRef,
+				},
+			}
+
+			r.handleReferencedObjects(chn, expectedRequest, false, tlog.NullLogger{})
+
+			for _, key := range cms {
+				cm := &corev1.ConfigMap{}
+				if err := r.Get(context.TODO(), key, cm); err != nil {
+					t.Fatalf("failed to get configmap %v: %v", key, err)
+				}
+
+				if labeled := cm.GetLabels()[chv1.ServingChannel] == "true"; labeled != (key == tC.wantLabeled) {
+					t.Errorf("configmap %v wanted labeled %v, got labels %v", key, key == tC.wantLabeled, cm.GetLabels())
+				}
+			}
+		})
+	}
+}
+
+func TestRequeueOnConflict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	r := &ReconcileChannel{
+		Log: tlog.NullLogger{},
+		conflictBackoff: &conflictBackoff{
+			backoff: wait.Backoff{
+				Duration: 10 * time.Millisecond,
+				Factor:   2,
+				Steps:    10,
+				Cap:      80 * time.Millisecond,
+			},
+			channels: make(map[types.NamespacedName]*wait.Backoff),
+		},
+	}
+
+	key := expectedRequest.NamespacedName
+	conflictErr := kerr.NewConflict(chv1.Resource("channels"), key.Name, gerr.New("object has been modified"))
+
+	for _, want := range []time.Duration{10, 20, 40, 80, 80} {
+		res, err := r.requeueOnConflict(key, reconcile.Result{}, gerr.Wrap(conflictErr, "failed to update channel"))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(res.RequeueAfter).To(gomega.Equal(want * time.Millisecond))
+	}
+
+	otherKey := types.NamespacedName{Name: "bar", Namespace: targetNamespace}
+	res, _ := r.requeueOnConflict(otherKey, reconcile.Result{}, conflictErr)
+	g.Expect(res.RequeueAfter).To(gomega.Equal(10 * time.Millisecond))
+
+	res, err := r.requeueOnConflict(key, reconcile.Result{}, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(res.RequeueAfter).To(gomega.BeZero())
+
+	res, _ = r.requeueOnConflict(key, reconcile.Result{}, conflictErr)
+	g.Expect(res.RequeueAfter).To(gomega.Equal(10 * time.Millisecond))
+
+	otherErr := gerr.New("failed to create rbac")
+	_, err = r.requeueOnConflict(key, reconcile.Result{}, otherErr)
+	g.Expect(err).To(gomega.Equal(otherErr))
+}
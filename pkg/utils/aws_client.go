@@ -18,11 +18,13 @@ import (
 	"bytes"
 	"context"
 	"io/ioutil"
+	"net/http"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
 	"k8s.io/klog"
 )
 
@@ -39,6 +41,13 @@ type ObjectStore interface {
 
 var _ ObjectStore = &AWSHandler{}
 
+// ContextObjectStore is implemented by the object stores passing the caller context to the bucket access.
+type ContextObjectStore interface {
+	ExistsWithContext(ctx context.Context, bucket string) error
+}
+
+var _ ContextObjectStore = &AWSHandler{}
+
 const (
 	// SecretMapKeyAccessKeyID is key of accesskeyid in secret
 	SecretMapKeyAccessKeyID = "AccessKeyID"
@@ -178,7 +187,12 @@ func (h *AWSHandler) Create(bucket string) error {
 
 // Exists Checks whether a bucket exists and is accessible.
 func (h *AWSHandler) Exists(bucket string) error {
-	_, err := h.Client.HeadBucket(context.TODO(), &s3.HeadBucketInput{
+	return h.ExistsWithContext(context.TODO(), bucket)
+}
+
+// ExistsWithContext checks whether a bucket exists and is accessible, the request is bound to the context.
+func (h *AWSHandler) ExistsWithContext(ctx context.Context, bucket string) error {
+	_, err := h.Client.HeadBucket(ctx, &s3.HeadBucketInput{
 		Bucket: &bucket,
 	})
 
@@ -191,6 +205,53 @@ func (h *AWSHandler) Exists(bucket string) error {
 	return nil
 }
 
+// statusCoder is implemented by the aws sdk errors carrying the http response status.
+type statusCoder interface {
+	HTTPStatusCode() int
+}
+
+// ValidateObjectStoreCredentials checks the bucket is accessible with the credentials the store is connected with,
+// so a misconfigured objectbucket channel is reported before its first sync. The context bounds the bucket access
+// if the store implements ContextObjectStore, otherwise it is only checked before the access.
+func ValidateObjectStoreCredentials(ctx context.Context, store ObjectStore, bucket string) error {
+	if store == nil {
+		return errors.New("object store connection is not initialized")
+	}
+
+	if bucket == "" {
+		return errors.New("empty bucket name")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return errors.Wrapf(err, "failed to validate access to bucket %v", bucket)
+	}
+
+	var err error
+
+	if cs, ok := store.(ContextObjectStore); ok {
+		err = cs.ExistsWithContext(ctx, bucket)
+	} else {
+		err = store.Exists(bucket)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		switch sc.HTTPStatusCode() {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return errors.Wrapf(err, "access to bucket %v is denied, check the %v and %v of the channel secret",
+				bucket, SecretMapKeyAccessKeyID, SecretMapKeySecretAccessKey)
+		case http.StatusNotFound:
+			return errors.Wrapf(err, "bucket %v is not found, check the channel pathname", bucket)
+		}
+	}
+
+	return errors.Wrapf(err, "failed to access bucket %v, check the channel pathname and secret", bucket)
+}
+
 // List all objects in bucket.
 func (h *AWSHandler) List(bucket string) ([]string, error) {
 	klog.V(1).Info("List S3 Objects ", bucket)
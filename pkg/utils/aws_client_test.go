@@ -0,0 +1,157 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+// statusError mimics the aws sdk response error
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return http.StatusText(e.code)
+}
+
+func (e *statusError) HTTPStatusCode() int {
+	return e.code
+}
+
+// deniedObjectStore fails every bucket access with the given error
+type deniedObjectStore struct {
+	utils.FakeObjectStore
+	err error
+}
+
+func (m *deniedObjectStore) Exists(bucket string) error {
+	return m.err
+}
+
+type ctxKey string
+
+// contextObjectStore only grants access when the bucket access carries the caller context
+type contextObjectStore struct {
+	utils.FakeObjectStore
+}
+
+func (m *contextObjectStore) ExistsWithContext(ctx context.Context, bucket string) error {
+	if ctx.Value(ctxKey("caller")) == nil {
+		return &statusError{code: http.StatusForbidden}
+	}
+
+	return nil
+}
+
+func TestValidateObjectStoreCredentials(t *testing.T) {
+	bucket := "ch-bucket"
+
+	fakeStore := &utils.FakeObjectStore{}
+	if err := fakeStore.InitObjectStoreConnection("", "", "", ""); err != nil {
+		t.Fatalf("failed to init the fake object store, %v", err)
+	}
+
+	canceledCtx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	testCases := []struct {
+		desc    string
+		ctx     context.Context
+		store   utils.ObjectStore
+		bucket  string
+		wantErr string
+	}{
+		{
+			desc:   "accessible bucket",
+			ctx:    context.TODO(),
+			store:  fakeStore,
+			bucket: bucket,
+		},
+		{
+			desc:   "caller context passed to the store",
+			ctx:    context.WithValue(context.TODO(), ctxKey("caller"), true),
+			store:  &contextObjectStore{},
+			bucket: bucket,
+		},
+		{
+			desc:    "caller context missing in the store",
+			ctx:     context.TODO(),
+			store:   &contextObjectStore{},
+			bucket:  bucket,
+			wantErr: "access to bucket ch-bucket is denied",
+		},
+		{
+			desc:    "access denied",
+			ctx:     context.TODO(),
+			store:   &deniedObjectStore{err: errors.Wrap(&statusError{code: http.StatusForbidden}, "head bucket")},
+			bucket:  bucket,
+			wantErr: "access to bucket ch-bucket is denied",
+		},
+		{
+			desc:    "missing bucket",
+			ctx:     context.TODO(),
+			store:   &deniedObjectStore{err: &statusError{code: http.StatusNotFound}},
+			bucket:  bucket,
+			wantErr: "bucket ch-bucket is not found",
+		},
+		{
+			desc:    "unreachable endpoint",
+			ctx:     context.TODO(),
+			store:   &deniedObjectStore{err: errors.New("dial tcp: connection refused")},
+			bucket:  bucket,
+			wantErr: "failed to access bucket ch-bucket",
+		},
+		{
+			desc:    "empty bucket name",
+			ctx:     context.TODO(),
+			store:   fakeStore,
+			bucket:  "",
+			wantErr: "empty bucket name",
+		},
+		{
+			desc:    "canceled context",
+			ctx:     canceledCtx,
+			store:   fakeStore,
+			bucket:  bucket,
+			wantErr: "failed to validate access to bucket ch-bucket",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := utils.ValidateObjectStoreCredentials(tC.ctx, tC.store, tC.bucket)
+
+			if tC.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateObjectStoreCredentials() wanted no error, got %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+				t.Errorf("ValidateObjectStoreCredentials() wanted error containing %q, got %v", tC.wantErr, err)
+			}
+		})
+	}
+}
@@ -18,10 +18,18 @@ import (
 	"context"
 	"fmt"
 
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
 
@@ -69,6 +77,135 @@ func LocateChannel(cl client.Client, name string) (*chv1.Channel, error) {
 	return nil, nil
 }
 
+// ErrChannelNamespaceUnavailable is returned by ValidateChannelNamespaceExists when the channel namespace
+// doesn't exist or is terminating
+var ErrChannelNamespaceUnavailable = errors.New("channel namespace is unavailable")
+
+// ValidateChannelNamespaceExists makes sure the namespace hosting the channel exists and is not terminating,
+// so the resources generated into it won't fail with an obscure error. A missing or terminating namespace
+// is reported as ErrChannelNamespaceUnavailable, other errors come from the API server.
+func ValidateChannelNamespaceExists(cl client.Client, channel *chv1.Channel) error {
+	return ValidateChannelNamespaceExistsWithContext(context.TODO(), cl, channel)
+}
+
+// ValidateChannelNamespaceExistsWithContext is ValidateChannelNamespaceExists looking up the namespace with
+// the caller context
+func ValidateChannelNamespaceExistsWithContext(ctx context.Context, cl client.Client, channel *chv1.Channel) error {
+	if channel == nil {
+		return errors.New("failed to validate channel namespace due to nil channel")
+	}
+
+	chKey := types.NamespacedName{Name: channel.GetName(), Namespace: channel.GetNamespace()}
+
+	ns := &corev1.Namespace{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: channel.GetNamespace()}, ns); err != nil {
+		if kerr.IsNotFound(err) {
+			return errors.Wrapf(ErrChannelNamespaceUnavailable, "namespace %v of channel %v doesn't exist",
+				channel.GetNamespace(), chKey.String())
+		}
+
+		return errors.Wrapf(err, "failed to get namespace %v of channel %v", channel.GetNamespace(), chKey.String())
+	}
+
+	if ns.GetDeletionTimestamp() != nil || ns.Status.Phase == corev1.NamespaceTerminating {
+		return errors.Wrapf(ErrChannelNamespaceUnavailable, "namespace %v of channel %v is terminating",
+			channel.GetNamespace(), chKey.String())
+	}
+
+	return nil
+}
+
+// ValidateChannelGates makes sure the gate annotation keys and values can be stamped on resources and read
+// back unchanged, that is, they are valid UTF-8 and free of control characters such as newlines
+func ValidateChannelGates(gates *chv1.ChannelGate) error {
+	if gates == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(gates.Annotations))
+	for k := range gates.Annotations {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var errs []error
+
+	for _, k := range keys {
+		if err := validateAnnotationText(k); err != nil {
+			errs = append(errs, errors.Wrapf(err, "invalid gate annotation key %q", k))
+			continue
+		}
+
+		if msgs := validation.IsQualifiedName(k); len(msgs) != 0 {
+			errs = append(errs, errors.Errorf("invalid gate annotation key %q: %v", k, strings.Join(msgs, ", ")))
+			continue
+		}
+
+		if err := validateAnnotationText(gates.Annotations[k]); err != nil {
+			errs = append(errs, errors.Wrapf(err, "invalid value of gate annotation %v", k))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateChannel checks the channel spec is consistent on its own, without looking up any other resource.
+// All the problems found are returned as an aggregated error
+func ValidateChannel(channel *chv1.Channel) error {
+	if channel == nil {
+		return errors.New("failed to validate nil channel")
+	}
+
+	var errs []error
+
+	for _, srcNs := range channel.Spec.SourceNamespaces {
+		if msgs := validation.IsDNS1123Label(srcNs); len(msgs) != 0 {
+			errs = append(errs, errors.Errorf("invalid source namespace %v: %v", srcNs, strings.Join(msgs, ", ")))
+		}
+	}
+
+	if err := ValidateChannelGates(channel.Spec.Gates); err != nil {
+		var agg utilerrors.Aggregate
+		if errors.As(err, &agg) {
+			errs = append(errs, agg.Errors()...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	// a namespace channel can only serve the namespace it lives in
+	if strings.EqualFold(string(channel.Spec.Type), chv1.ChannelTypeNamespace) {
+		if strings.TrimSpace(channel.Spec.Pathname) != "" {
+			pathname, err := NormalizePathName(chv1.ChannelTypeNamespace, channel.Spec.Pathname)
+
+			switch {
+			case err != nil:
+				errs = append(errs, err)
+			case pathname != channel.GetNamespace():
+				errs = append(errs, errors.Errorf("namespace channel %v/%v can't point to namespace %v",
+					channel.GetNamespace(), channel.GetName(), pathname))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateAnnotationText(s string) error {
+	if !utf8.ValidString(s) {
+		return errors.New("not valid UTF-8")
+	}
+
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return errors.Errorf("contains control character %U", r)
+		}
+	}
+
+	return nil
+}
+
 // UpdateServingChannel add/remove the given channel to the current serving channel
 func UpdateServingChannel(servingChannel string, channelKey string, action string) string {
 	parsedstr := strings.Split(servingChannel, ",")
@@ -93,16 +230,18 @@ func UpdateServingChannel(servingChannel string, channelKey string, action strin
 		}
 	}
 
-	newChannelList := ""
+	// keep the order stable, so an unchanged serving channel doesn't show up as an update of the object
+	newChannels := make([]string, 0, len(newChannelMap))
+
 	for newch := range newChannelMap {
-		if newChannelList > "" {
-			newChannelList += ","
+		if newch != "" {
+			newChannels = append(newChannels, newch)
 		}
-
-		newChannelList += newch
 	}
 
-	return newChannelList
+	sort.Strings(newChannels)
+
+	return strings.Join(newChannels, ",")
 }
 
 func ParseSecertInfo(secret *corev1.Secret) (username string, password string, region string) {
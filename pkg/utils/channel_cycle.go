@@ -0,0 +1,115 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+)
+
+// CyclePath is a list of channels where each channel promotes from the namespace of the previous one,
+// and the first channel promotes from the namespace of the last one
+type CyclePath []types.NamespacedName
+
+// DetectPromotionCycles finds the channels promoting from each other's namespace via the sourceNamespaces
+func DetectPromotionCycles(cl client.Client) ([]CyclePath, error) {
+	chlist := &chv1.ChannelList{}
+	if err := cl.List(context.TODO(), chlist, &client.ListOptions{}); err != nil {
+		return nil, errors.Wrap(err, "failed to list channels for promotion cycle detection")
+	}
+
+	return findPromotionCycles(chlist.Items), nil
+}
+
+func findPromotionCycles(channels []chv1.Channel) []CyclePath {
+	nodes := make([]types.NamespacedName, 0, len(channels))
+	byNamespace := make(map[string][]types.NamespacedName)
+	sources := make(map[types.NamespacedName][]string)
+
+	for _, ch := range channels {
+		key := types.NamespacedName{Name: ch.GetName(), Namespace: ch.GetNamespace()}
+		nodes = append(nodes, key)
+		byNamespace[key.Namespace] = append(byNamespace[key.Namespace], key)
+		sources[key] = ch.Spec.SourceNamespaces
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].String() < nodes[j].String() })
+
+	// the content flows from the channels of a source namespace to the channel sourcing from it, so
+	// the upstream of a channel is every channel living in one of its source namespaces
+	upstream := func(key types.NamespacedName) []types.NamespacedName {
+		ups := []types.NamespacedName{}
+		for _, ns := range sources[key] {
+			ups = append(ups, byNamespace[ns]...)
+		}
+
+		sort.Slice(ups, func(i, j int) bool { return ups[i].String() < ups[j].String() })
+
+		return ups
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[types.NamespacedName]int)
+	stack := []types.NamespacedName{}
+	cycles := []CyclePath{}
+
+	var visit func(key types.NamespacedName)
+
+	visit = func(key types.NamespacedName) {
+		state[key] = visiting
+		stack = append(stack, key)
+
+		for _, up := range upstream(key) {
+			switch state[up] {
+			case unvisited:
+				visit(up)
+			case visiting:
+				// walking the stack down to the upstream channel lists the cycle in the promotion direction
+				path := CyclePath{}
+
+				for i := len(stack) - 1; i >= 0; i-- {
+					path = append(path, stack[i])
+					if stack[i] == up {
+						break
+					}
+				}
+
+				cycles = append(cycles, path)
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[key] = visited
+	}
+
+	for _, key := range nodes {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+
+	return cycles
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+func newSourcingChannel(name, namespace string, sourceNamespaces ...string) *chv1.Channel {
+	return &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: chv1.ChannelSpec{
+			Type:             chv1.ChannelTypeNamespace,
+			Pathname:         namespace,
+			SourceNamespaces: sourceNamespaces,
+		},
+	}
+}
+
+func TestDetectPromotionCycles(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		channels []*chv1.Channel
+		want     []utils.CyclePath
+	}{
+		{
+			desc: "acyclic channels",
+			channels: []*chv1.Channel{
+				newSourcingChannel("dev", "cycle-dev"),
+				newSourcingChannel("qa", "cycle-qa", "cycle-dev"),
+				newSourcingChannel("prod", "cycle-prod", "cycle-qa"),
+			},
+			want: []utils.CyclePath{},
+		},
+		{
+			desc: "two channels sourcing from each other",
+			channels: []*chv1.Channel{
+				newSourcingChannel("dev", "cycle-dev", "cycle-qa"),
+				newSourcingChannel("qa", "cycle-qa", "cycle-dev"),
+				newSourcingChannel("prod", "cycle-prod", "cycle-qa"),
+			},
+			want: []utils.CyclePath{
+				{
+					{Name: "qa", Namespace: "cycle-qa"},
+					{Name: "dev", Namespace: "cycle-dev"},
+				},
+			},
+		},
+		{
+			desc: "channel sourcing from its own namespace",
+			channels: []*chv1.Channel{
+				newSourcingChannel("dev", "cycle-dev", "cycle-dev"),
+			},
+			want: []utils.CyclePath{
+				{
+					{Name: "dev", Namespace: "cycle-dev"},
+				},
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			ctx := context.TODO()
+
+			for _, ch := range tC.channels {
+				g.Expect(c.Create(ctx, ch)).NotTo(gomega.HaveOccurred())
+				defer c.Delete(ctx, ch)
+			}
+
+			got, err := utils.DetectPromotionCycles(c)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.Equal(tC.want))
+		})
+	}
+}
@@ -21,9 +21,6 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
-	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
@@ -94,14 +91,14 @@ func (desc *ChannelDescriptor) ConnectWithResourceHost(chn *chv1.Channel, kubeCl
 
 	var accessID, secretAccessKey, region string
 
-	var err error
-
 	if chn.Spec.SecretRef != nil {
-		accessID, secretAccessKey, region, err = getCredentialFromKube(chn.Spec.SecretRef, chn.GetNamespace(), kubeClient)
+		srt, err := ResolveChannelSecret(kubeClient, chn)
 		if err != nil {
 			log.Error(err, "failed to fetch the reference secret")
 			return err
 		}
+
+		accessID, secretAccessKey, region = ParseSecertInfo(srt)
 	}
 	// Add new channel to the map
 	if err := desc.updateChannelRegistry(chn, accessID, secretAccessKey, region, storageHanler, log); err != nil {
@@ -112,33 +109,6 @@ func (desc *ChannelDescriptor) ConnectWithResourceHost(chn *chv1.Channel, kubeCl
 	return nil
 }
 
-func getCredentialFromKube(secretRef *corev1.ObjectReference, defaultNs string, kubeClient client.Client) (string, string, string, error) {
-	if secretRef == nil {
-		return "", "", "", errors.New("failed to get access info to objectstore due to missing referred secret")
-	}
-
-	accessKeyID := ""
-	secretAccessKey := ""
-	region := ""
-
-	secret := &corev1.Secret{}
-	secns := secretRef.Namespace
-
-	if secns == "" {
-		secns = defaultNs
-	}
-
-	err := kubeClient.Get(context.TODO(), types.NamespacedName{Name: secretRef.Name, Namespace: secns}, secret)
-
-	if err != nil {
-		return "", "", "", errors.Wrap(err, "unable to get secret")
-	}
-
-	accessKeyID, secretAccessKey, region = ParseSecertInfo(secret)
-
-	return accessKeyID, secretAccessKey, region, nil
-}
-
 func parseBucketAndEndpoint(pathName string) (string, string) {
 	if pathName == "" {
 		return "", ""
@@ -149,7 +119,15 @@ func parseBucketAndEndpoint(pathName string) (string, string) {
 		pathName = pathName[:last]
 	}
 
+	if strings.HasPrefix(pathName, S3Scheme) {
+		return S3Scheme, pathName[len(S3Scheme):]
+	}
+
 	loc := strings.LastIndex(pathName, "/")
+	if loc == -1 {
+		return "", pathName
+	}
+
 	endpoint := pathName[:loc]
 	bucket := pathName[loc+1:]
 
@@ -160,7 +138,13 @@ func (desc *ChannelDescriptor) updateChannelRegistry(chn *chv1.Channel, accessKe
 	objStoreHandler ObjectStore, log logr.Logger) error {
 	chndesc := &ChannelDescription{}
 
-	endpoint, bucket := parseBucketAndEndpoint(chn.Spec.Pathname)
+	pathName, err := NormalizePathName(chv1.ChannelTypeObjectBucket, chn.Spec.Pathname)
+	if err != nil {
+		log.Error(err, "invalid object store pathname")
+		return err
+	}
+
+	endpoint, bucket := parseBucketAndEndpoint(pathName)
 
 	chndesc.Bucket = bucket
 
@@ -171,7 +155,7 @@ func (desc *ChannelDescriptor) updateChannelRegistry(chn *chv1.Channel, accessKe
 		return err
 	}
 	// Check whether the connection is setup successfully
-	if err := objStoreHandler.Exists(chndesc.Bucket); err != nil {
+	if err := ValidateObjectStoreCredentials(context.TODO(), objStoreHandler, chndesc.Bucket); err != nil {
 		log.Error(err, fmt.Sprint("unable to access object store bucket ", chndesc.Bucket, " for channel ", chn.Name))
 		return err
 	}
@@ -0,0 +1,89 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+)
+
+// EnsureChannelFinalizer adds the cleanup finalizer to the channel, it doesn't update the channel if the
+// finalizer is already present. The channel is only changed once the update succeeds, so a failed update,
+// i.e. a conflict, leaves it as it was
+func EnsureChannelFinalizer(cl client.Client, channel *chv1.Channel) error {
+	if channel == nil {
+		return errors.New("failed to add finalizer due to nil channel")
+	}
+
+	if hasChannelFinalizer(channel) {
+		return nil
+	}
+
+	updated := channel.DeepCopy()
+	updated.SetFinalizers(append(updated.GetFinalizers(), chv1.ChannelCleanupFinalizer))
+
+	if err := cl.Update(context.TODO(), updated); err != nil {
+		return errors.Wrapf(err, "failed to add finalizer to channel %v/%v", channel.GetNamespace(), channel.GetName())
+	}
+
+	updated.DeepCopyInto(channel)
+
+	return nil
+}
+
+// RemoveChannelFinalizer removes the cleanup finalizer from the channel, it doesn't update the channel if
+// the finalizer is already absent. Same as EnsureChannelFinalizer, a failed update leaves the channel as it was
+func RemoveChannelFinalizer(cl client.Client, channel *chv1.Channel) error {
+	if channel == nil {
+		return errors.New("failed to remove finalizer due to nil channel")
+	}
+
+	if !hasChannelFinalizer(channel) {
+		return nil
+	}
+
+	finalizers := []string{}
+
+	for _, f := range channel.GetFinalizers() {
+		if f != chv1.ChannelCleanupFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+
+	updated := channel.DeepCopy()
+	updated.SetFinalizers(finalizers)
+
+	if err := cl.Update(context.TODO(), updated); err != nil {
+		return errors.Wrapf(err, "failed to remove finalizer from channel %v/%v", channel.GetNamespace(), channel.GetName())
+	}
+
+	updated.DeepCopyInto(channel)
+
+	return nil
+}
+
+func hasChannelFinalizer(channel *chv1.Channel) bool {
+	for _, f := range channel.GetFinalizers() {
+		if f == chv1.ChannelCleanupFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
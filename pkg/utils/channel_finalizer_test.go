@@ -0,0 +1,96 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+func TestChannelFinalizer(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.TODO()
+
+	key := types.NamespacedName{Name: "ch-finalizer", Namespace: "default"}
+	otherFinalizer := "example.com/other"
+
+	chn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       key.Name,
+			Namespace:  key.Namespace,
+			Finalizers: []string{otherFinalizer},
+		},
+		Spec: chv1.ChannelSpec{
+			Type:     chv1.ChannelTypeNamespace,
+			Pathname: key.Namespace,
+		},
+	}
+
+	g.Expect(c.Create(ctx, chn)).NotTo(gomega.HaveOccurred())
+
+	defer func() {
+		chn.SetFinalizers(nil)
+		c.Update(ctx, chn)
+		c.Delete(ctx, chn)
+	}()
+
+	fetched := &chv1.Channel{}
+
+	for i := 0; i < 2; i++ {
+		g.Expect(utils.EnsureChannelFinalizer(c, chn)).NotTo(gomega.HaveOccurred())
+
+		g.Expect(c.Get(ctx, key, fetched)).NotTo(gomega.HaveOccurred())
+		g.Expect(fetched.GetFinalizers()).To(gomega.ConsistOf(otherFinalizer, chv1.ChannelCleanupFinalizer))
+	}
+
+	version := fetched.GetResourceVersion()
+	g.Expect(utils.EnsureChannelFinalizer(c, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(fetched.GetResourceVersion()).To(gomega.Equal(version))
+
+	for i := 0; i < 2; i++ {
+		g.Expect(utils.RemoveChannelFinalizer(c, chn)).NotTo(gomega.HaveOccurred())
+
+		g.Expect(c.Get(ctx, key, fetched)).NotTo(gomega.HaveOccurred())
+		g.Expect(fetched.GetFinalizers()).To(gomega.ConsistOf(otherFinalizer))
+	}
+
+	version = fetched.GetResourceVersion()
+	g.Expect(utils.RemoveChannelFinalizer(c, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(fetched.GetResourceVersion()).To(gomega.Equal(version))
+
+	// a conflicting update must leave the caller's channel as it was
+	stale := chn.DeepCopy()
+	g.Expect(utils.EnsureChannelFinalizer(c, chn)).NotTo(gomega.HaveOccurred())
+
+	staleVersion := stale.GetResourceVersion()
+	g.Expect(utils.EnsureChannelFinalizer(c, stale)).To(gomega.HaveOccurred())
+	g.Expect(stale.GetFinalizers()).To(gomega.ConsistOf(otherFinalizer))
+	g.Expect(stale.GetResourceVersion()).To(gomega.Equal(staleVersion))
+
+	stale = chn.DeepCopy()
+	g.Expect(utils.RemoveChannelFinalizer(c, chn)).NotTo(gomega.HaveOccurred())
+
+	staleVersion = stale.GetResourceVersion()
+	g.Expect(utils.RemoveChannelFinalizer(c, stale)).To(gomega.HaveOccurred())
+	g.Expect(stale.GetFinalizers()).To(gomega.ConsistOf(otherFinalizer, chv1.ChannelCleanupFinalizer))
+	g.Expect(stale.GetResourceVersion()).To(gomega.Equal(staleVersion))
+}
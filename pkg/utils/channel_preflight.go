@@ -0,0 +1,179 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+)
+
+// PreflightObjectStore creates the object store PreflightCheckChannel checks the bucket access of an
+// objectbucket channel with
+var PreflightObjectStore = func() ObjectStore {
+	return &AWSHandler{}
+}
+
+// PreflightCheckChannel runs every channel validation against the given channel without applying it, and
+// returns all the problems found instead of stopping at the first one
+func PreflightCheckChannel(ctx context.Context, cl client.Client, channel *chv1.Channel) []error {
+	if channel == nil {
+		return []error{errors.New("failed to preflight check due to nil channel")}
+	}
+
+	var errs []error
+
+	chType := string(channel.Spec.Type)
+	pathname := channel.Spec.Pathname
+
+	// the pathname of a namespace channel is checked by ValidateChannel, and filled in by the reconciler when empty
+	pathOK := strings.EqualFold(chType, chv1.ChannelTypeNamespace)
+
+	if !pathOK {
+		normalized, err := NormalizePathName(chType, pathname)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			pathname, pathOK = normalized, true
+		}
+	}
+
+	if err := ValidateChannel(channel); err != nil {
+		var agg utilerrors.Aggregate
+		if errors.As(err, &agg) {
+			errs = append(errs, agg.Errors()...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, checkSourceNamespaces(ctx, cl, channel.Spec.SourceNamespaces)...)
+
+	if err := ValidateChannelNamespaceExistsWithContext(ctx, cl, channel); err != nil {
+		errs = append(errs, err)
+	}
+
+	var srt *corev1.Secret
+
+	srtOK := true
+
+	if channel.Spec.SecretRef != nil {
+		srt = &corev1.Secret{}
+		if err := resolveChannelReference(ctx, cl, channel, channel.Spec.SecretRef, "secret", srt); err != nil {
+			errs = append(errs, err)
+			srtOK = false
+		}
+	}
+
+	if channel.Spec.ConfigMapRef != nil {
+		if err := resolveChannelReference(ctx, cl, channel, channel.Spec.ConfigMapRef, "configmap", &corev1.ConfigMap{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// the bucket access can only be checked with a valid pathname and the referred credentials
+	if strings.EqualFold(chType, chv1.ChannelTypeObjectBucket) && pathOK && srtOK {
+		if err := checkObjectBucketAccess(ctx, pathname, srt); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, checkPromotionCycles(ctx, cl, channel)...)
+
+	return errs
+}
+
+func checkObjectBucketAccess(ctx context.Context, pathname string, srt *corev1.Secret) error {
+	endpoint, bucket := parseBucketAndEndpoint(pathname)
+
+	var accessKeyID, secretAccessKey, region string
+
+	if srt != nil {
+		accessKeyID, secretAccessKey, region = ParseSecertInfo(srt)
+	}
+
+	store := PreflightObjectStore()
+	if err := store.InitObjectStoreConnection(endpoint, accessKeyID, secretAccessKey, region); err != nil {
+		return errors.Wrapf(err, "failed to connect to object store %v", endpoint)
+	}
+
+	return ValidateObjectStoreCredentials(ctx, store, bucket)
+}
+
+// checkPromotionCycles reports the promotion cycles the channel would be part of once applied
+func checkPromotionCycles(ctx context.Context, cl client.Client, channel *chv1.Channel) []error {
+	chlist := &chv1.ChannelList{}
+	if err := cl.List(ctx, chlist, &client.ListOptions{}); err != nil {
+		return []error{errors.Wrap(err, "failed to list channels for promotion cycle detection")}
+	}
+
+	chKey := types.NamespacedName{Name: channel.GetName(), Namespace: channel.GetNamespace()}
+	channels := []chv1.Channel{*channel.DeepCopy()}
+
+	for _, ch := range chlist.Items {
+		if ch.GetName() != chKey.Name || ch.GetNamespace() != chKey.Namespace {
+			channels = append(channels, ch)
+		}
+	}
+
+	var errs []error
+
+	for _, path := range findPromotionCycles(channels) {
+		keys := make([]string, 0, len(path))
+		inPath := false
+
+		for _, key := range path {
+			keys = append(keys, key.String())
+			inPath = inPath || key == chKey
+		}
+
+		if inPath {
+			errs = append(errs, errors.Errorf("channel %v is in the promotion cycle %v", chKey.String(), strings.Join(keys, " -> ")))
+		}
+	}
+
+	return errs
+}
+
+// checkSourceNamespaces makes sure the source namespaces exist, the invalid names are reported by ValidateChannel
+func checkSourceNamespaces(ctx context.Context, cl client.Client, srcNamespaces []string) []error {
+	var errs []error
+
+	for _, srcNs := range srcNamespaces {
+		if len(validation.IsDNS1123Label(srcNs)) != 0 {
+			continue
+		}
+
+		if err := cl.Get(ctx, types.NamespacedName{Name: srcNs}, &corev1.Namespace{}); err != nil {
+			if kerr.IsNotFound(err) {
+				errs = append(errs, errors.Errorf("source namespace %v doesn't exist", srcNs))
+				continue
+			}
+
+			errs = append(errs, errors.Wrapf(err, "failed to get source namespace %v", srcNs))
+		}
+	}
+
+	return errs
+}
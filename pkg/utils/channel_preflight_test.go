@@ -0,0 +1,134 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+func TestPreflightCheckChannel(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.TODO()
+
+	refSrt := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ch-preflight-srt",
+			Namespace: "default",
+		},
+	}
+
+	g.Expect(c.Create(ctx, refSrt)).NotTo(gomega.HaveOccurred())
+	defer c.Delete(ctx, refSrt)
+
+	validChn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "qa",
+			Namespace: "default",
+		},
+		Spec: chv1.ChannelSpec{
+			Type:             chv1.ChannelTypeHelmRepo,
+			Pathname:         "https://charts.example.com/stable/",
+			SourceNamespaces: []string{"kube-system"},
+			SecretRef:        &corev1.ObjectReference{Name: refSrt.GetName()},
+			Gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev-ready": "true"},
+			},
+		},
+	}
+
+	g.Expect(utils.PreflightCheckChannel(ctx, c, validChn)).To(gomega.BeEmpty())
+
+	brokenChn := validChn.DeepCopy()
+	brokenChn.SetNamespace("ch-preflight-missing")
+	brokenChn.Spec.Pathname = "ftp://charts.example.com"
+	brokenChn.Spec.SourceNamespaces = []string{"Dev_NS", "ch-preflight-src-missing"}
+	brokenChn.Spec.SecretRef = &corev1.ObjectReference{Name: "ch-preflight-srt-missing"}
+	brokenChn.Spec.Gates.Annotations["dev-ready"] = "true\n"
+
+	errs := utils.PreflightCheckChannel(ctx, c, brokenChn)
+
+	msgs := []string{}
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	all := strings.Join(msgs, "\n")
+
+	g.Expect(errs).To(gomega.HaveLen(6), all)
+
+	for _, want := range []string{
+		"invalid helmrepo pathname",
+		"dev-ready",
+		"invalid source namespace Dev_NS",
+		"source namespace ch-preflight-src-missing doesn't exist",
+		"namespace ch-preflight-missing of channel",
+		"referred secret ch-preflight-missing/ch-preflight-srt-missing",
+	} {
+		g.Expect(all).To(gomega.ContainSubstring(want))
+	}
+
+	// the reconciler fills in the pathname of a namespace channel
+	nsChn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "qa-ns",
+			Namespace: "default",
+		},
+		Spec: chv1.ChannelSpec{
+			Type: chv1.ChannelTypeNamespace,
+		},
+	}
+
+	g.Expect(utils.PreflightCheckChannel(ctx, c, nsChn)).To(gomega.BeEmpty())
+
+	cycleChn := nsChn.DeepCopy()
+	cycleChn.Spec.SourceNamespaces = []string{"default"}
+
+	errs = utils.PreflightCheckChannel(ctx, c, cycleChn)
+	g.Expect(errs).To(gomega.HaveLen(1))
+	g.Expect(errs[0].Error()).To(gomega.ContainSubstring("channel default/qa-ns is in the promotion cycle"))
+
+	defaultStore := utils.PreflightObjectStore
+	defer func() {
+		utils.PreflightObjectStore = defaultStore
+	}()
+
+	utils.PreflightObjectStore = func() utils.ObjectStore {
+		return &deniedObjectStore{err: &statusError{code: http.StatusForbidden}}
+	}
+
+	objChn := validChn.DeepCopy()
+	objChn.Spec.Type = chv1.ChannelTypeObjectBucket
+	objChn.Spec.Pathname = "https://minio.example.com/ch-bucket"
+
+	errs = utils.PreflightCheckChannel(ctx, c, objChn)
+	g.Expect(errs).To(gomega.HaveLen(1))
+	g.Expect(errs[0].Error()).To(gomega.ContainSubstring("access to bucket ch-bucket is denied"))
+
+	utils.PreflightObjectStore = func() utils.ObjectStore {
+		return &utils.FakeObjectStore{}
+	}
+
+	g.Expect(utils.PreflightCheckChannel(ctx, c, objChn)).To(gomega.BeEmpty())
+}
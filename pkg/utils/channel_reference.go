@@ -0,0 +1,98 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+)
+
+// ErrInvalidChannelReference is returned when a channel refers to an object without a name, or outside of
+// the channel namespace
+var ErrInvalidChannelReference = errors.New("invalid channel reference")
+
+// ResolveChannelSecret gets the secret referred by the channel spec.secretRef, it returns nil if the channel
+// doesn't refer to a secret
+func ResolveChannelSecret(cl client.Client, channel *chv1.Channel) (*corev1.Secret, error) {
+	if channel == nil {
+		return nil, errors.New("failed to resolve referred secret due to nil channel")
+	}
+
+	if channel.Spec.SecretRef == nil {
+		return nil, nil
+	}
+
+	srt := &corev1.Secret{}
+	if err := resolveChannelReference(context.TODO(), cl, channel, channel.Spec.SecretRef, "secret", srt); err != nil {
+		return nil, err
+	}
+
+	return srt, nil
+}
+
+// ResolveChannelConfigMap gets the configmap referred by the channel spec.configMapRef, it returns nil if the
+// channel doesn't refer to a configmap
+func ResolveChannelConfigMap(cl client.Client, channel *chv1.Channel) (*corev1.ConfigMap, error) {
+	if channel == nil {
+		return nil, errors.New("failed to resolve referred configmap due to nil channel")
+	}
+
+	if channel.Spec.ConfigMapRef == nil {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := resolveChannelReference(context.TODO(), cl, channel, channel.Spec.ConfigMapRef, "configmap", cm); err != nil {
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+// resolveChannelReference gets the object referred by the channel into obj, the referred object has to live
+// in the channel namespace
+func resolveChannelReference(ctx context.Context, cl client.Client, channel *chv1.Channel, ref *corev1.ObjectReference,
+	kind string, obj runtime.Object) error {
+	chKey := types.NamespacedName{Name: channel.GetName(), Namespace: channel.GetNamespace()}
+
+	if ref.Name == "" {
+		return errors.Wrapf(ErrInvalidChannelReference, "referred %v of channel %v has an empty name", kind, chKey.String())
+	}
+
+	if ref.Namespace != "" && ref.Namespace != channel.GetNamespace() {
+		return errors.Wrapf(ErrInvalidChannelReference, "referred %v %v/%v of channel %v is not in the channel namespace",
+			kind, ref.Namespace, ref.Name, chKey.String())
+	}
+
+	objKey := types.NamespacedName{Name: ref.Name, Namespace: channel.GetNamespace()}
+
+	if err := cl.Get(ctx, objKey, obj); err != nil {
+		if kerr.IsNotFound(err) {
+			return errors.Wrapf(err, "referred %v %v of channel %v doesn't exist", kind, objKey.String(), chKey.String())
+		}
+
+		return errors.Wrapf(err, "failed to get referred %v %v of channel %v", kind, objKey.String(), chKey.String())
+	}
+
+	return nil
+}
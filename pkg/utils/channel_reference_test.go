@@ -0,0 +1,139 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+func TestResolveChannelReference(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.TODO()
+
+	refSrt := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ch-resolve-srt",
+			Namespace: "default",
+		},
+	}
+
+	refCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ch-resolve-cm",
+			Namespace: "default",
+		},
+	}
+
+	g.Expect(c.Create(ctx, refSrt)).NotTo(gomega.HaveOccurred())
+	defer c.Delete(ctx, refSrt)
+
+	g.Expect(c.Create(ctx, refCm)).NotTo(gomega.HaveOccurred())
+	defer c.Delete(ctx, refCm)
+
+	testCases := []struct {
+		desc        string
+		srtRef      *corev1.ObjectReference
+		cmRef       *corev1.ObjectReference
+		wantSrt     bool
+		wantCm      bool
+		wantErrs    int
+		wantInvalid bool
+	}{
+		{
+			desc: "without refs",
+		},
+		{
+			desc:    "refs in the channel namespace",
+			srtRef:  &corev1.ObjectReference{Name: refSrt.GetName()},
+			cmRef:   &corev1.ObjectReference{Name: refCm.GetName(), Namespace: "default"},
+			wantSrt: true,
+			wantCm:  true,
+		},
+		{
+			desc:     "refs to missing objects",
+			srtRef:   &corev1.ObjectReference{Name: "ch-resolve-srt-missing"},
+			cmRef:    &corev1.ObjectReference{Name: "ch-resolve-cm-missing"},
+			wantErrs: 2,
+		},
+		{
+			desc:        "refs to another namespace",
+			srtRef:      &corev1.ObjectReference{Name: refSrt.GetName(), Namespace: "kube-system"},
+			cmRef:       &corev1.ObjectReference{Name: refCm.GetName(), Namespace: "kube-system"},
+			wantErrs:    2,
+			wantInvalid: true,
+		},
+		{
+			desc:        "refs without name",
+			srtRef:      &corev1.ObjectReference{},
+			cmRef:       &corev1.ObjectReference{},
+			wantErrs:    2,
+			wantInvalid: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			chn := &chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "qa",
+					Namespace: "default",
+				},
+				Spec: chv1.ChannelSpec{
+					Type:         chv1.ChannelTypeHelmRepo,
+					Pathname:     "https://charts.example.com",
+					SecretRef:    tC.srtRef,
+					ConfigMapRef: tC.cmRef,
+				},
+			}
+
+			gotErrs := 0
+
+			srt, err := utils.ResolveChannelSecret(c, chn)
+			if err != nil {
+				gotErrs++
+			}
+
+			if errors.Is(err, utils.ErrInvalidChannelReference) != tC.wantInvalid {
+				t.Errorf("ResolveChannelSecret() wanted invalid reference %v, got %v", tC.wantInvalid, err)
+			}
+
+			cm, err := utils.ResolveChannelConfigMap(c, chn)
+			if err != nil {
+				gotErrs++
+			}
+
+			if gotErrs != tC.wantErrs {
+				t.Errorf("wanted %v errors, got %v", tC.wantErrs, gotErrs)
+			}
+
+			if (srt != nil) != tC.wantSrt || (srt != nil && srt.GetName() != refSrt.GetName()) {
+				t.Errorf("ResolveChannelSecret() wanted secret %v, got %v", tC.wantSrt, srt)
+			}
+
+			if (cm != nil) != tC.wantCm || (cm != nil && cm.GetName() != refCm.GetName()) {
+				t.Errorf("ResolveChannelConfigMap() wanted configmap %v, got %v", tC.wantCm, cm)
+			}
+		})
+	}
+}
@@ -22,8 +22,11 @@ import (
 	tlog "github.com/go-logr/logr/testing"
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
 	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
@@ -197,3 +200,238 @@ func convertCommaStringToMap(s string) map[string]bool {
 
 	return m
 }
+
+func TestValidateChannelNamespaceExists(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.TODO()
+
+	terminatingNs := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ch-terminating",
+		},
+	}
+
+	g.Expect(c.Create(ctx, terminatingNs)).NotTo(gomega.HaveOccurred())
+	// the envtest doesn't run the namespace controller, so the namespace stays in terminating phase
+	g.Expect(c.Delete(ctx, terminatingNs)).NotTo(gomega.HaveOccurred())
+
+	testCases := []struct {
+		desc    string
+		chNs    string
+		wantErr bool
+	}{
+		{
+			desc:    "existing namespace",
+			chNs:    "default",
+			wantErr: false,
+		},
+		{
+			desc:    "missing namespace",
+			chNs:    "ch-missing",
+			wantErr: true,
+		},
+		{
+			desc:    "terminating namespace",
+			chNs:    terminatingNs.GetName(),
+			wantErr: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			chn := &chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "qa",
+					Namespace: tC.chNs,
+				},
+				Spec: chv1.ChannelSpec{
+					Type: chv1.ChannelTypeNamespace,
+				},
+			}
+
+			err := utils.ValidateChannelNamespaceExists(c, chn)
+			if (err != nil) != tC.wantErr {
+				t.Errorf("ValidateChannelNamespaceExists() wanted error %v, got %v", tC.wantErr, err)
+			}
+
+			if err != nil && !errors.Is(err, utils.ErrChannelNamespaceUnavailable) {
+				t.Errorf("ValidateChannelNamespaceExists() wanted ErrChannelNamespaceUnavailable, got %v", err)
+			}
+		})
+	}
+
+	// the namespace lookup has to follow the caller context
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	chn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "qa",
+			Namespace: "default",
+		},
+	}
+
+	err := utils.ValidateChannelNamespaceExistsWithContext(canceledCtx, c, chn)
+	if err == nil || errors.Is(err, utils.ErrChannelNamespaceUnavailable) {
+		t.Errorf("ValidateChannelNamespaceExistsWithContext() wanted the canceled context error, got %v", err)
+	}
+}
+
+func TestValidateChannelGates(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		gates   *chv1.ChannelGate
+		wantErr bool
+	}{
+		{
+			desc:    "nil gates",
+			gates:   nil,
+			wantErr: false,
+		},
+		{
+			desc: "clean value",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev-ready": "true"},
+			},
+			wantErr: false,
+		},
+		{
+			desc: "value with a newline",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev-ready": "true\n"},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "value with invalid UTF-8",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev-ready": "tr\xffue"},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "key with a tab",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev\tready": "true"},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "prefixed key",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"apps.open-cluster-management.io/dev-ready": "true"},
+			},
+			wantErr: false,
+		},
+		{
+			desc: "key not a qualified name",
+			gates: &chv1.ChannelGate{
+				Annotations: map[string]string{"dev ready!": "true"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := utils.ValidateChannelGates(tC.gates)
+			if (err != nil) != tC.wantErr {
+				t.Errorf("ValidateChannelGates() wanted error %v, got %v", tC.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateChannelSpec(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		spec     chv1.ChannelSpec
+		wantErrs int
+	}{
+		{
+			desc: "valid namespace channel",
+			spec: chv1.ChannelSpec{
+				Type:             chv1.ChannelTypeNamespace,
+				Pathname:         "ch-qa",
+				SourceNamespaces: []string{"dev", "staging"},
+				Gates: &chv1.ChannelGate{
+					Annotations: map[string]string{"dev-ready": "true"},
+				},
+			},
+			wantErrs: 0,
+		},
+		{
+			desc: "namespace channel with a trailing slash",
+			spec: chv1.ChannelSpec{
+				Type:     chv1.ChannelTypeNamespace,
+				Pathname: " ch-qa/",
+			},
+			wantErrs: 0,
+		},
+		{
+			desc: "namespace channel with an invalid pathname",
+			spec: chv1.ChannelSpec{
+				Type:     chv1.ChannelTypeNamespace,
+				Pathname: "Ch_QA",
+			},
+			wantErrs: 1,
+		},
+		{
+			desc: "namespace channel pointing to another namespace",
+			spec: chv1.ChannelSpec{
+				Type:     chv1.ChannelTypeNamespace,
+				Pathname: "ch-prod",
+			},
+			wantErrs: 1,
+		},
+		{
+			desc: "helmrepo channel pointing to a repo",
+			spec: chv1.ChannelSpec{
+				Type:     chv1.ChannelTypeHelmRepo,
+				Pathname: "https://charts.example.com",
+			},
+			wantErrs: 0,
+		},
+		{
+			desc: "every check failing",
+			spec: chv1.ChannelSpec{
+				Type:             chv1.ChannelTypeNamespace,
+				Pathname:         "ch-prod",
+				SourceNamespaces: []string{"Dev_NS", "staging", "-qa"},
+				Gates: &chv1.ChannelGate{
+					Annotations: map[string]string{"dev ready!": "true", "qa-ready": "true\n"},
+				},
+			},
+			wantErrs: 5,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			chn := &chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "qa",
+					Namespace: "ch-qa",
+				},
+				Spec: tC.spec,
+			}
+
+			err := utils.ValidateChannel(chn)
+
+			gotErrs := 0
+			if agg, ok := err.(utilerrors.Aggregate); ok {
+				gotErrs = len(agg.Errors())
+			} else if err != nil {
+				gotErrs = 1
+			}
+
+			if gotErrs != tC.wantErrs {
+				t.Errorf("ValidateChannel() wanted %v errors, got %v", tC.wantErrs, err)
+			}
+		})
+	}
+
+	if err := utils.ValidateChannel(nil); err == nil {
+		t.Errorf("ValidateChannel() wanted error for nil channel")
+	}
+}
@@ -19,13 +19,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/ghodss/yaml"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/helm/pkg/repo"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
 )
 
 const (
@@ -76,6 +81,11 @@ func buildRepoURL(repoURL string) string {
 
 func GetChartIndex(chnPathname string, insecureSkipVerify bool, srt *corev1.Secret,
 	chnRefCfgMap *corev1.ConfigMap, logger logr.Logger) (*http.Response, error) {
+	chnPathname, err := NormalizePathName(chv1.ChannelTypeHelmRepo, chnPathname)
+	if err != nil {
+		return nil, err
+	}
+
 	repoURL := buildRepoURL(chnPathname)
 
 	client := decideHTTPClient(repoURL, insecureSkipVerify, chnRefCfgMap, logger)
@@ -148,5 +158,140 @@ func GetHelmRepoIndex(
 		return nil, errors.Wrap(err, fmt.Sprintf("unable to unmarshal repo %v", buildRepoURL(channelPathName)))
 	}
 
+	if skipped := ValidateHelmRepoIndex(i); len(skipped) != 0 {
+		logger.Info(fmt.Sprintf("skipped malformed entries of %v: %v", buildRepoURL(channelPathName), skipped))
+	}
+
 	return i, nil
 }
+
+// ValidateHelmRepoIndex drops the chart versions missing the version or urls field from the index,
+// a chart without any valid version left is removed as well. It returns the description of the
+// skipped entries.
+func ValidateHelmRepoIndex(idx *repo.IndexFile) []string {
+	skipped := []string{}
+
+	if idx == nil {
+		return skipped
+	}
+
+	for chartName, chartVersions := range idx.Entries {
+		validVersions := repo.ChartVersions{}
+
+		for n, cv := range chartVersions {
+			switch {
+			case cv == nil || cv.Metadata == nil:
+				skipped = append(skipped, fmt.Sprintf("%v[%v]: empty entry", chartName, n))
+			case cv.Version == "":
+				skipped = append(skipped, fmt.Sprintf("%v[%v]: missing version", chartName, n))
+			case len(cv.URLs) == 0:
+				skipped = append(skipped, fmt.Sprintf("%v-%v: missing urls", chartName, cv.Version))
+			default:
+				validVersions = append(validVersions, cv)
+			}
+		}
+
+		if len(validVersions) == 0 {
+			delete(idx.Entries, chartName)
+			continue
+		}
+
+		idx.Entries[chartName] = validVersions
+	}
+
+	return skipped
+}
+
+// GetChannelHelmRepoIndex gets the index file of a HelmRepo type channel, only the chart versions
+// satisfying the channel version constraint annotation are kept. Like GetHelmRepoIndex, it is not called
+// by the channel operator; it is for the subscription operator, which syncs the helm repo charts.
+func GetChannelHelmRepoIndex(
+	chn *chv1.Channel,
+	chnRefSrt *corev1.Secret,
+	chnRefCfgMap *corev1.ConfigMap,
+	loadIdx LoadIndexPageFunc,
+	logger logr.Logger) (*repo.IndexFile, error) {
+	if chn == nil {
+		return nil, errors.New("failed to get helm repo index due to nil channel")
+	}
+
+	constraint := chn.GetAnnotations()[chv1.KeyChannelVersionConstraint]
+
+	// parse the constraint ahead, a broken constraint fails the sync instead of promoting every version
+	if _, err := parseVersionConstraint(constraint); err != nil {
+		return nil, errors.Wrapf(err, "channel %v/%v", chn.GetNamespace(), chn.GetName())
+	}
+
+	idx, err := GetHelmRepoIndex(chn.Spec.Pathname, chn.Spec.InsecureSkipVerify, chnRefSrt, chnRefCfgMap, loadIdx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, err := FilterHelmRepoIndexByVersion(idx, constraint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "channel %v/%v", chn.GetNamespace(), chn.GetName())
+	}
+
+	if len(skipped) != 0 {
+		logger.V(1).Info(fmt.Sprintf("skipped chart versions not satisfying %v: %v", constraint, skipped))
+	}
+
+	return idx, nil
+}
+
+// FilterHelmRepoIndexByVersion drops the chart versions not satisfying the semver constraint from the index,
+// a chart without any version left is removed as well. An empty constraint keeps every version.
+// It returns the skipped chart versions. It is used by GetChannelHelmRepoIndex.
+func FilterHelmRepoIndexByVersion(idx *repo.IndexFile, constraint string) ([]string, error) {
+	skipped := []string{}
+
+	c, err := parseVersionConstraint(constraint)
+	if err != nil || c == nil || idx == nil {
+		return skipped, err
+	}
+
+	for chartName, chartVersions := range idx.Entries {
+		validVersions := repo.ChartVersions{}
+
+		for _, cv := range chartVersions {
+			if cv == nil || cv.Metadata == nil {
+				continue
+			}
+
+			v, err := semver.NewVersion(cv.Version)
+			if err != nil || !c.Check(v) {
+				skipped = append(skipped, fmt.Sprintf("%v-%v", chartName, cv.Version))
+				continue
+			}
+
+			validVersions = append(validVersions, cv)
+		}
+
+		if len(validVersions) == 0 {
+			delete(idx.Entries, chartName)
+			continue
+		}
+
+		idx.Entries[chartName] = validVersions
+	}
+
+	return skipped, nil
+}
+
+// spaceSeparatedRange matches the space between two ranges of a constraint, i.e. ">=2.0.0 <3.0.0"
+var spaceSeparatedRange = regexp.MustCompile(`([0-9A-Za-z*.+-])\s+([<>=!~^])`)
+
+// parseVersionConstraint parses the constraint, the ranges can be separated by comma or space as in npm
+func parseVersionConstraint(constraint string) (*semver.Constraints, error) {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return nil, nil
+	}
+
+	c, err := semver.NewConstraint(spaceSeparatedRange.ReplaceAllString(constraint, "$1, $2"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version constraint %q in annotation %v", constraint, chv1.KeyChannelVersionConstraint)
+	}
+
+	return c, nil
+}
@@ -15,14 +15,23 @@
 package utils
 
 import (
+	"sort"
 	"testing"
 
 	tlog "github.com/go-logr/logr/testing"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/helm/pkg/repo"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
 )
 
 const (
 	helmTests     = "../../tests/helm/testhelm"
 	helmChartsNum = 2
+
+	helmMalformedTests = "../../tests/helm/malformed"
+	helmSemverTests    = "../../tests/helm/semver"
 )
 
 func TestGetHelmRepoIndex(t *testing.T) {
@@ -36,3 +45,147 @@ func TestGetHelmRepoIndex(t *testing.T) {
 		t.Errorf("faild to parse helm chart, wanted %v, got %v", helmChartsNum, len(idx.Entries))
 	}
 }
+
+func TestGetHelmRepoIndexWithMalformedEntries(t *testing.T) {
+	idx, err := GetHelmRepoIndex(helmMalformedTests, false, nil, nil, LoadLocalIdx, tlog.NullLogger{})
+
+	if err != nil {
+		t.Errorf("failed to load index %+v", err)
+	}
+
+	if len(idx.Entries) != 1 {
+		t.Errorf("failed to skip the chart without valid version, wanted 1, got %v", len(idx.Entries))
+	}
+
+	if len(idx.Entries["gbapp"]) != 1 || idx.Entries["gbapp"][0].Version != "0.1.0" {
+		t.Errorf("failed to skip the chart version without urls, got %v", idx.Entries["gbapp"])
+	}
+}
+
+func TestValidateHelmRepoIndex(t *testing.T) {
+	if got := ValidateHelmRepoIndex(nil); len(got) != 0 {
+		t.Errorf("nil index should not report skipped entries, got %v", got)
+	}
+
+	idx := &repo.IndexFile{
+		Entries: map[string]repo.ChartVersions{
+			"empty": {nil},
+		},
+	}
+
+	if got := ValidateHelmRepoIndex(idx); len(got) != 1 || len(idx.Entries) != 0 {
+		t.Errorf("failed to skip empty entry, skipped %v, left %v", got, idx.Entries)
+	}
+}
+
+func TestFilterHelmRepoIndexByVersion(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		constraint string
+		want       map[string][]string
+		wantErr    bool
+	}{
+		{
+			desc:       "empty constraint",
+			constraint: "",
+			want: map[string][]string{
+				"gbapp":   {"1.9.0", "2.0.0", "2.1.0-beta.1", "2.5.3", "3.0.0", "latest"},
+				"gbapp-1": {"1.0.0"},
+			},
+		},
+		{
+			desc:       "range constraint skips pre-release",
+			constraint: ">=2.0.0 <3.0.0",
+			want: map[string][]string{
+				"gbapp": {"2.0.0", "2.5.3"},
+			},
+		},
+		{
+			desc:       "pre-release constraint",
+			constraint: ">=2.1.0-0 <3.0.0-0",
+			want: map[string][]string{
+				"gbapp": {"2.1.0-beta.1", "2.5.3"},
+			},
+		},
+		{
+			desc:       "comma separated constraint",
+			constraint: ">= 2.0.0, < 3.0.0",
+			want: map[string][]string{
+				"gbapp": {"2.0.0", "2.5.3"},
+			},
+		},
+		{
+			desc:       "exact version constraint",
+			constraint: "2.5.3",
+			want: map[string][]string{
+				"gbapp": {"2.5.3"},
+			},
+		},
+		{
+			desc:       "invalid constraint",
+			constraint: ">=two",
+			wantErr:    true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			idx, err := GetHelmRepoIndex(helmSemverTests, false, nil, nil, LoadLocalIdx, tlog.NullLogger{})
+			if err != nil {
+				t.Fatalf("failed to load index %+v", err)
+			}
+
+			_, err = FilterHelmRepoIndexByVersion(idx, tC.constraint)
+			if (err != nil) != tC.wantErr {
+				t.Fatalf("FilterHelmRepoIndexByVersion() wanted error %v, got %v", tC.wantErr, err)
+			}
+
+			if tC.wantErr {
+				return
+			}
+
+			got := map[string][]string{}
+
+			for chartName, chartVersions := range idx.Entries {
+				for _, cv := range chartVersions {
+					got[chartName] = append(got[chartName], cv.Version)
+				}
+
+				sort.Strings(got[chartName])
+			}
+
+			if diff := cmp.Diff(tC.want, got); diff != "" {
+				t.Errorf("FilterHelmRepoIndexByVersion() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetChannelHelmRepoIndex(t *testing.T) {
+	chn := &chv1.Channel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "helm",
+			Namespace:   "ch-helm",
+			Annotations: map[string]string{chv1.KeyChannelVersionConstraint: ">=2.0.0 <3.0.0"},
+		},
+		Spec: chv1.ChannelSpec{
+			Type:     chv1.ChannelTypeHelmRepo,
+			Pathname: helmSemverTests,
+		},
+	}
+
+	idx, err := GetChannelHelmRepoIndex(chn, nil, nil, LoadLocalIdx, tlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("failed to load index %+v", err)
+	}
+
+	if len(idx.Entries) != 1 || len(idx.Entries["gbapp"]) != 2 {
+		t.Errorf("failed to filter index by the version constraint, got %v", idx.Entries)
+	}
+
+	chn.Annotations[chv1.KeyChannelVersionConstraint] = "~>"
+
+	if _, err := GetChannelHelmRepoIndex(chn, nil, nil, LoadLocalIdx, tlog.NullLogger{}); err == nil {
+		t.Errorf("invalid version constraint should fail the index loading")
+	}
+}
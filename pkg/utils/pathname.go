@@ -0,0 +1,118 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+)
+
+const (
+	// S3Scheme is the prefix of a AWS S3 bucket pathname, i.e. s3://bucket
+	S3Scheme = "s3://"
+)
+
+// scpLikeGitURL matches the git ssh short form, i.e. git@github.com:org/repo.git
+var scpLikeGitURL = regexp.MustCompile(`^[A-Za-z0-9._~-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// NormalizePathName validates the pathname of the given channel type and returns its canonical form
+// For `namespace` channel, the pathname is a namespace name;
+// For `helmrepo` channel, the pathname is a http(s) URL without trailing slash;
+// For `objectbucket` channel, the pathname is a http(s) URL ending with the bucket name, or s3://bucket.
+// A bare bucket name is converted to s3://bucket;
+// For `github` and `git` channel, the pathname is a http(s)/ssh URL or the scp-like git@host:path form.
+func NormalizePathName(channelType, pathname string) (string, error) {
+	pathname = strings.TrimRight(strings.TrimSpace(pathname), "/")
+
+	if pathname == "" {
+		return "", errors.Errorf("empty pathname for %v channel", channelType)
+	}
+
+	switch {
+	case strings.EqualFold(channelType, chv1.ChannelTypeNamespace):
+		if errs := validation.IsDNS1123Label(pathname); len(errs) != 0 {
+			return "", errors.Errorf("invalid namespace pathname %v: %v", pathname, strings.Join(errs, ", "))
+		}
+
+		return pathname, nil
+	case strings.EqualFold(channelType, chv1.ChannelTypeHelmRepo):
+		return normalizeURL(pathname, channelType, "http", "https")
+	case strings.EqualFold(channelType, chv1.ChannelTypeObjectBucket):
+		return normalizeObjectBucketPathName(pathname)
+	case strings.EqualFold(channelType, chv1.ChannelTypeGitHub), strings.EqualFold(channelType, chv1.ChannelTypeGit):
+		if scpLikeGitURL.MatchString(pathname) {
+			return pathname, nil
+		}
+
+		return normalizeURL(pathname, channelType, "http", "https", "ssh", "git")
+	}
+
+	return "", errors.Errorf("unknown channel type %v", channelType)
+}
+
+func normalizeObjectBucketPathName(pathname string) (string, error) {
+	if strings.HasPrefix(strings.ToLower(pathname), S3Scheme) {
+		bucket := pathname[len(S3Scheme):]
+		if bucket == "" || strings.Contains(bucket, "/") {
+			return "", errors.Errorf("invalid objectbucket pathname %v, expecting s3://bucket", pathname)
+		}
+
+		return S3Scheme + bucket, nil
+	}
+
+	if !strings.Contains(pathname, "/") {
+		return S3Scheme + pathname, nil
+	}
+
+	normalized, err := normalizeURL(pathname, chv1.ChannelTypeObjectBucket, "http", "https")
+	if err != nil {
+		return "", err
+	}
+
+	u, _ := url.Parse(normalized)
+	if strings.Trim(u.Path, "/") == "" {
+		return "", errors.Errorf("invalid objectbucket pathname %v, missing the bucket name", pathname)
+	}
+
+	return normalized, nil
+}
+
+func normalizeURL(pathname, channelType string, schemes ...string) (string, error) {
+	u, err := url.Parse(pathname)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %v pathname %v", channelType, pathname)
+	}
+
+	if u.Host == "" {
+		return "", errors.Errorf("invalid %v pathname %v, missing host", channelType, pathname)
+	}
+
+	for _, s := range schemes {
+		if strings.EqualFold(u.Scheme, s) {
+			u.Scheme = s
+			u.Host = strings.ToLower(u.Host)
+
+			return u.String(), nil
+		}
+	}
+
+	return "", errors.Errorf("invalid %v pathname %v, expecting scheme in %v", channelType, pathname, schemes)
+}
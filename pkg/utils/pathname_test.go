@@ -0,0 +1,148 @@
+// Copyright 2019 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"testing"
+
+	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
+)
+
+func TestNormalizePathName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		chType   string
+		pathname string
+		want     string
+		wantErr  bool
+	}{
+		{
+			desc:     "namespace with trailing slash",
+			chType:   chv1.ChannelTypeNamespace,
+			pathname: "ch-qa/",
+			want:     "ch-qa",
+		},
+		{
+			desc:     "namespace with invalid name",
+			chType:   "Namespace",
+			pathname: "Ch_QA",
+			wantErr:  true,
+		},
+		{
+			desc:     "helmrepo with trailing slashes",
+			chType:   chv1.ChannelTypeHelmRepo,
+			pathname: "https://charts.example.com/stable//",
+			want:     "https://charts.example.com/stable",
+		},
+		{
+			desc:     "helmrepo with upper case scheme and host",
+			chType:   "HelmRepo",
+			pathname: "HTTPS://Charts.Example.com/stable",
+			want:     "https://charts.example.com/stable",
+		},
+		{
+			desc:     "helmrepo without scheme",
+			chType:   chv1.ChannelTypeHelmRepo,
+			pathname: "charts.example.com/stable",
+			wantErr:  true,
+		},
+		{
+			desc:     "helmrepo with git scheme",
+			chType:   chv1.ChannelTypeHelmRepo,
+			pathname: "git@github.com:org/charts.git",
+			wantErr:  true,
+		},
+		{
+			desc:     "objectbucket with s3 scheme",
+			chType:   chv1.ChannelTypeObjectBucket,
+			pathname: "s3://bucket/",
+			want:     "s3://bucket",
+		},
+		{
+			desc:     "objectbucket with bare bucket",
+			chType:   chv1.ChannelTypeObjectBucket,
+			pathname: "bucket",
+			want:     "s3://bucket",
+		},
+		{
+			desc:     "objectbucket with https endpoint",
+			chType:   chv1.ChannelTypeObjectBucket,
+			pathname: "https://minio.example.com:9000/bucket/",
+			want:     "https://minio.example.com:9000/bucket",
+		},
+		{
+			desc:     "objectbucket endpoint without bucket",
+			chType:   chv1.ChannelTypeObjectBucket,
+			pathname: "https://minio.example.com:9000/",
+			wantErr:  true,
+		},
+		{
+			desc:     "objectbucket with nested s3 path",
+			chType:   chv1.ChannelTypeObjectBucket,
+			pathname: "s3://bucket/folder",
+			wantErr:  true,
+		},
+		{
+			desc:     "git with https and trailing slash",
+			chType:   chv1.ChannelTypeGit,
+			pathname: "https://github.com/org/repo.git/",
+			want:     "https://github.com/org/repo.git",
+		},
+		{
+			desc:     "github with scp-like url",
+			chType:   chv1.ChannelTypeGitHub,
+			pathname: "git@github.com:org/repo.git",
+			want:     "git@github.com:org/repo.git",
+		},
+		{
+			desc:     "git with ssh url",
+			chType:   "Git",
+			pathname: "ssh://git@github.com/org/repo.git",
+			want:     "ssh://git@github.com/org/repo.git",
+		},
+		{
+			desc:     "git without host",
+			chType:   chv1.ChannelTypeGit,
+			pathname: "org/repo",
+			wantErr:  true,
+		},
+		{
+			desc:     "empty pathname",
+			chType:   chv1.ChannelTypeGit,
+			pathname: " / ",
+			wantErr:  true,
+		},
+		{
+			desc:     "unknown type",
+			chType:   "ftp",
+			pathname: "ftp://example.com/repo",
+			wantErr:  true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := utils.NormalizePathName(tC.chType, tC.pathname)
+			if (err != nil) != tC.wantErr {
+				t.Fatalf("NormalizePathName() wanted error %v, got %v", tC.wantErr, err)
+			}
+
+			if got != tC.want {
+				t.Errorf("NormalizePathName() wanted %v, got %v", tC.want, got)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/go-logr/logr"
 
 	chv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	"open-cluster-management.io/multicloud-operators-channel/pkg/utils"
 )
 
 type ChannelValidator struct {
@@ -53,6 +54,10 @@ func (v *ChannelValidator) Handle(ctx context.Context, req admission.Request) ad
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	if err := utils.ValidateChannel(chn); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	chnType := string(chn.Spec.Type)
 	if strings.EqualFold(chnType, chv1.ChannelTypeGit) || strings.EqualFold(chnType, chv1.ChannelTypeGitHub) {
 		return admission.Allowed("")
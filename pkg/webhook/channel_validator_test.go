@@ -61,6 +61,17 @@ var _ = Describe("test channel validation logic", func() {
 			}()
 		})
 
+		It("should not create channel with a newline in gate annotation value", func() {
+			gatedChn := chnIns.DeepCopy()
+			gatedChn.Spec.Type = chv1.ChannelTypeGit
+			gatedChn.SetName("gated-chn1")
+			gatedChn.Spec.Gates = &chv1.ChannelGate{
+				Annotations: map[string]string{"dev-ready": "true\n"},
+			}
+
+			Expect(k8sClient.Create(context.TODO(), gatedChn)).ShouldNot(Succeed())
+		})
+
 		It("should not create 2nd  namespace channel", func() {
 			dupChn := chnIns.DeepCopy()
 			dupChn.SetName("dup-chn1")
@@ -165,6 +176,46 @@ var _ = Describe("test channel validation logic", func() {
 		})
 	})
 
+	Context("given a namespace without channel", func() {
+		var (
+			chkey  = types.NamespacedName{Name: "ch1", Namespace: "kube-public"}
+			chnIns = chv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      chkey.Name,
+					Namespace: chkey.Namespace},
+				Spec: chv1.ChannelSpec{
+					Type:     chv1.ChannelType(chv1.ChannelTypeNamespace),
+					Pathname: chkey.Namespace,
+				},
+			}
+		)
+
+		It("should create namespace channel with a trailing slash in pathname", func() {
+			nsChn := chnIns.DeepCopy()
+			nsChn.Spec.Pathname = chkey.Namespace + "/"
+
+			Expect(k8sClient.Create(context.TODO(), nsChn)).Should(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(context.TODO(), nsChn)).Should(Succeed())
+			}()
+		})
+
+		It("should not create namespace channel pointing to another namespace", func() {
+			nsChn := chnIns.DeepCopy()
+			nsChn.Spec.Pathname = "kube-system"
+
+			Expect(k8sClient.Create(context.TODO(), nsChn)).ShouldNot(Succeed())
+		})
+
+		It("should not create channel with an invalid source namespace", func() {
+			srcChn := chnIns.DeepCopy()
+			srcChn.Spec.Type = chv1.ChannelTypeGit
+			srcChn.Spec.SourceNamespaces = []string{"Dev_NS"}
+
+			Expect(k8sClient.Create(context.TODO(), srcChn)).ShouldNot(Succeed())
+		})
+	})
+
 	Context("given an exist objectbucket channel in a namespace", func() {
 		var (
 			chkey  = types.NamespacedName{Name: "ch1", Namespace: "default"}